@@ -0,0 +1,85 @@
+package internal
+
+import "go/types"
+
+// BaseTypeKind classifies a BaseType for callers that need to branch on it
+// (marshaling, -kind=flags, the exhaustiveness checker) without repeating
+// string comparisons against Name.
+type BaseTypeKind string
+
+const (
+	BaseKindInt    BaseTypeKind = "int"
+	BaseKindUint   BaseTypeKind = "uint"
+	BaseKindFloat  BaseTypeKind = "float"
+	BaseKindString BaseTypeKind = "string"
+	BaseKindBool   BaseTypeKind = "bool"
+	BaseKindOther  BaseTypeKind = "other"
+)
+
+// BaseType is the resolved underlying type of a genum-annotated type, e.g.
+// "int" for `type Status int` or `type Status = OtherStatus` where
+// OtherStatus is an int.
+type BaseType struct {
+	Kind BaseTypeKind
+	Name string
+}
+
+func (b BaseType) IsInteger() bool {
+	return b.Kind == BaseKindInt || b.Kind == BaseKindUint
+}
+
+func (b BaseType) IsString() bool {
+	return b.Kind == BaseKindString
+}
+
+func (b BaseType) String() string {
+	return b.Name
+}
+
+// resolveBaseType walks typ down to the BaseType the generator can branch
+// on. It unwraps alias types (type Status = OtherStatus) and named types
+// (type Status int, including generic declarations such as
+// type Status[T ~int] int) by following each to its underlying
+// representation.
+//
+// There is deliberately no case for *types.TypeParam: ParseBaseType only
+// ever calls this on the type of a package-scope *types.TypeName, which for
+// a generic type declaration is the generic *types.Named itself, not one of
+// its type parameters. A bare TypeParam only appears inside a generic
+// function or method's own type, which genum never looks up this way.
+func resolveBaseType(typ types.Type) BaseType {
+	typ = types.Unalias(typ)
+
+	switch t := typ.(type) {
+	case *types.Basic:
+		return basicBaseType(t)
+	case *types.Named:
+		return resolveBaseType(t.Underlying())
+	case *types.Pointer:
+		return BaseType{Kind: BaseKindOther, Name: "*" + resolveBaseType(t.Elem()).Name}
+	case *types.Struct:
+		return BaseType{Kind: BaseKindOther, Name: "struct{}"}
+	}
+
+	return BaseType{Kind: BaseKindOther, Name: "unsupported"}
+}
+
+func basicBaseType(b *types.Basic) BaseType {
+	name := b.Name()
+	info := b.Info()
+
+	switch {
+	case info&types.IsUnsigned != 0:
+		return BaseType{Kind: BaseKindUint, Name: name}
+	case info&types.IsInteger != 0:
+		return BaseType{Kind: BaseKindInt, Name: name}
+	case info&types.IsFloat != 0:
+		return BaseType{Kind: BaseKindFloat, Name: name}
+	case info&types.IsString != 0:
+		return BaseType{Kind: BaseKindString, Name: name}
+	case info&types.IsBoolean != 0:
+		return BaseType{Kind: BaseKindBool, Name: name}
+	default:
+		return BaseType{Kind: BaseKindOther, Name: name}
+	}
+}
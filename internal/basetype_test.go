@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sv3tluv/genum/internal"
+)
+
+func loadBaseTypeFixture(t *testing.T, files map[string]string) *packages.Package {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.21\n"), 0644))
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0644))
+	}
+
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	return pkgs[0]
+}
+
+func TestParser_ParseBaseType_Alias(t *testing.T) {
+	pkg := loadBaseTypeFixture(t, map[string]string{"fixture.go": `package fixture
+
+type OtherStatus int
+
+type Status = OtherStatus
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+`})
+
+	parser := internal.NewParser()
+	baseType := parser.ParseBaseType(pkg, "Status")
+	require.NotNil(t, baseType)
+	require.True(t, baseType.IsInteger())
+	require.Equal(t, "int", baseType.Name)
+}
+
+func TestParser_ParseBaseType_GenericDeclaration(t *testing.T) {
+	pkg := loadBaseTypeFixture(t, map[string]string{"fixture.go": `package fixture
+
+type Status[T ~int] int
+
+const (
+	StatusActive Status[int] = iota
+	StatusInactive
+)
+`})
+
+	parser := internal.NewParser()
+	baseType := parser.ParseBaseType(pkg, "Status")
+	require.NotNil(t, baseType)
+	require.True(t, baseType.IsInteger())
+	require.Equal(t, "int", baseType.Name)
+}
+
+func TestParser_ParseConstants_CrossFile(t *testing.T) {
+	pkg := loadBaseTypeFixture(t, map[string]string{
+		"types.go": `package fixture
+
+type Status int
+`,
+		"values.go": `package fixture
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+`,
+	})
+
+	parser := internal.NewParser()
+	values := parser.ParseConstants(pkg, "Status")
+	require.Len(t, values, 2)
+	require.Equal(t, "StatusActive", values[0].Name)
+	require.Equal(t, "StatusInactive", values[1].Name)
+}
+
+func TestParser_ParseConstants_MultiNameSpec(t *testing.T) {
+	pkg := loadBaseTypeFixture(t, map[string]string{"fixture.go": `package fixture
+
+type Status string
+
+const (
+	StatusActive, StatusInactive Status = "active", "inactive"
+)
+`})
+
+	parser := internal.NewParser()
+	values := parser.ParseConstants(pkg, "Status")
+	require.Len(t, values, 2)
+	require.Equal(t, "StatusActive", values[0].Name)
+	require.Equal(t, "active", values[0].Value)
+	require.Equal(t, "StatusInactive", values[1].Name)
+	require.Equal(t, "inactive", values[1].Value)
+}
@@ -0,0 +1,108 @@
+// Package config parses the project-wide .genum.yaml file that lets a
+// repository declare every enum it wants generated in one place, instead of
+// scattering //go:generate directives across source files.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the schema of a .genum.yaml file.
+type Config struct {
+	Defaults EnumConfig   `yaml:"defaults"`
+	Enums    []EnumConfig `yaml:"enums"`
+}
+
+// EnumConfig mirrors internal.Directive's fields so either source can drive
+// the same generator, plus Package to locate the enum's declaring package.
+type EnumConfig struct {
+	Package    string   `yaml:"package"`
+	Type       string   `yaml:"type"`
+	Output     string   `yaml:"output"`
+	TrimPrefix string   `yaml:"trimprefix"`
+	Case       string   `yaml:"case"`
+	Kind       string   `yaml:"kind"`
+	WireFormat string   `yaml:"wireformat"`
+	Marshal    []string `yaml:"marshal"`
+}
+
+// FileNames are the project-root config files Find looks for, in order.
+var FileNames = []string{".genum.yaml", ".genum.yml"}
+
+// Find looks for the first of FileNames starting in dir, then walks up
+// through dir's parents, stopping at the first directory containing a
+// go.mod (the module root) or at the filesystem root, whichever comes
+// first. A go:generate invocation runs with its cwd set to the package
+// directory containing the source file, which is frequently a subdirectory
+// of the module root where the project's .genum.yaml actually lives.
+func Find(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, true
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return "", false
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Load reads and parses the config file at path, filling any option an enum
+// entry leaves unset from Defaults. Package and Type are never taken from
+// Defaults.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	for i := range cfg.Enums {
+		cfg.Enums[i].applyDefaults(cfg.Defaults)
+	}
+
+	return &cfg, nil
+}
+
+func (e *EnumConfig) applyDefaults(defaults EnumConfig) {
+	if e.Case == "" {
+		e.Case = defaults.Case
+	}
+	if e.Kind == "" {
+		e.Kind = defaults.Kind
+	}
+	if e.WireFormat == "" {
+		e.WireFormat = defaults.WireFormat
+	}
+	if e.TrimPrefix == "" {
+		e.TrimPrefix = defaults.TrimPrefix
+	}
+	if e.Output == "" {
+		e.Output = defaults.Output
+	}
+	if len(e.Marshal) == 0 {
+		e.Marshal = defaults.Marshal
+	}
+}
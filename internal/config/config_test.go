@@ -0,0 +1,99 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv3tluv/genum/internal/config"
+)
+
+const fixtureYAML = `
+defaults:
+  case: ignore
+  marshal: [json]
+
+enums:
+  - package: ./pkg/status
+    type: Status
+    output: status_gen.go
+    trimprefix: Status_
+  - package: ./pkg/role
+    type: Role
+    case: sensitive
+    marshal: [json, sql]
+`
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".genum.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func TestLoad_AppliesDefaults(t *testing.T) {
+	path := writeConfig(t, fixtureYAML)
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Enums, 2)
+
+	status := cfg.Enums[0]
+	assert.Equal(t, "./pkg/status", status.Package)
+	assert.Equal(t, "Status", status.Type)
+	assert.Equal(t, "status_gen.go", status.Output)
+	assert.Equal(t, "Status_", status.TrimPrefix)
+	assert.Equal(t, "ignore", status.Case)
+	assert.Equal(t, []string{"json"}, status.Marshal)
+
+	role := cfg.Enums[1]
+	assert.Equal(t, "./pkg/role", role.Package)
+	assert.Equal(t, "Role", role.Type)
+	assert.Equal(t, "sensitive", role.Case)
+	assert.Equal(t, []string{"json", "sql"}, role.Marshal)
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := config.Load(filepath.Join(t.TempDir(), ".genum.yaml"))
+	require.Error(t, err)
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+
+	_, ok := config.Find(dir)
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".genum.yaml"), []byte(fixtureYAML), 0644))
+
+	path, ok := config.Find(dir)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, ".genum.yaml"), path)
+}
+
+func TestFind_WalksUpToModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".genum.yaml"), []byte(fixtureYAML), 0644))
+
+	pkgDir := filepath.Join(root, "internal", "pkg")
+	require.NoError(t, os.MkdirAll(pkgDir, 0755))
+
+	path, ok := config.Find(pkgDir)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(root, ".genum.yaml"), path)
+}
+
+func TestFind_StopsAtModuleRootWithoutConfig(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example\n\ngo 1.21\n"), 0644))
+
+	pkgDir := filepath.Join(root, "internal", "pkg")
+	require.NoError(t, os.MkdirAll(pkgDir, 0755))
+
+	_, ok := config.Find(pkgDir)
+	assert.False(t, ok)
+}
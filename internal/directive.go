@@ -24,11 +24,65 @@ func (c CaseHandling) IsValid() bool {
 	return false
 }
 
+type MarshalKind string
+
+const (
+	MarshalJSON MarshalKind = "json"
+	MarshalSQL  MarshalKind = "sql"
+	MarshalText MarshalKind = "text"
+)
+
+func (m MarshalKind) IsValid() bool {
+	switch m {
+	case MarshalJSON, MarshalSQL, MarshalText:
+		return true
+	}
+	return false
+}
+
+type WireFormat string
+
+const (
+	// WireFormatName uses the bare constant name, e.g. "StatusActive".
+	WireFormatName WireFormat = "name"
+	// WireFormatValue uses the enum's underlying value as-is.
+	WireFormatValue WireFormat = "value"
+	// WireFormatTrimmed uses the constant name with TrimPrefix removed and
+	// lowercased, e.g. "active". This is the default.
+	WireFormatTrimmed WireFormat = "trimmed"
+)
+
+func (w WireFormat) IsValid() bool {
+	switch w {
+	case WireFormatName, WireFormatValue, WireFormatTrimmed:
+		return true
+	}
+	return false
+}
+
+type EnumKind string
+
+const (
+	KindEnum  EnumKind = "enum"
+	KindFlags EnumKind = "flags"
+)
+
+func (k EnumKind) IsValid() bool {
+	switch k {
+	case KindEnum, KindFlags:
+		return true
+	}
+	return false
+}
+
 type Directive struct {
 	TypeName   string
 	OutputFile string
 	TrimPrefix string
 	Case       CaseHandling
+	Marshal    []MarshalKind
+	WireFormat WireFormat
+	Kind       EnumKind
 }
 
 func ParseFromComment(comment, sourceFile string) (*Directive, error) {
@@ -55,6 +109,16 @@ func ParseFromComment(comment, sourceFile string) (*Directive, error) {
 			d.TrimPrefix = v
 		case "-case":
 			d.Case = CaseHandling(v)
+		case "-marshal":
+			for _, token := range strings.Split(v, ",") {
+				if token = strings.TrimSpace(token); token != "" {
+					d.Marshal = append(d.Marshal, MarshalKind(token))
+				}
+			}
+		case "-wireformat":
+			d.WireFormat = WireFormat(v)
+		case "-kind":
+			d.Kind = EnumKind(v)
 		}
 	}
 
@@ -70,6 +134,23 @@ func ParseFromComment(comment, sourceFile string) (*Directive, error) {
 	if !d.Case.IsValid() {
 		return nil, fmt.Errorf("invalid argument -case: %s", d.Case)
 	}
+	for _, m := range d.Marshal {
+		if !m.IsValid() {
+			return nil, fmt.Errorf("invalid argument -marshal: %s", m)
+		}
+	}
+	if d.WireFormat == "" {
+		d.WireFormat = WireFormatTrimmed
+	}
+	if !d.WireFormat.IsValid() {
+		return nil, fmt.Errorf("invalid argument -wireformat: %s", d.WireFormat)
+	}
+	if d.Kind == "" {
+		d.Kind = KindEnum
+	}
+	if !d.Kind.IsValid() {
+		return nil, fmt.Errorf("invalid argument -kind: %s", d.Kind)
+	}
 
 	return d, nil
 }
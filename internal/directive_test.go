@@ -155,6 +155,60 @@ func TestParseFromComment(t *testing.T) {
 				TrimPrefix: "MyType123_",
 			},
 		},
+		{
+			name:       "valid comment with marshal flags",
+			comment:    `//go:generate genum -type=Status -marshal=json,sql,text`,
+			sourceFile: "status.go",
+			expected: &Directive{
+				TypeName:   "Status",
+				OutputFile: "status_genum.go",
+				TrimPrefix: "Status",
+				Marshal:    []MarshalKind{MarshalJSON, MarshalSQL, MarshalText},
+			},
+		},
+		{
+			name:          "invalid marshal flag",
+			comment:       `//go:generate genum -type=Status -marshal=xml`,
+			sourceFile:    "status.go",
+			expected:      nil,
+			expectedError: "invalid argument -marshal: xml",
+		},
+		{
+			name:       "valid comment with wireformat override",
+			comment:    `//go:generate genum -type=Status -wireformat=name`,
+			sourceFile: "status.go",
+			expected: &Directive{
+				TypeName:   "Status",
+				OutputFile: "status_genum.go",
+				TrimPrefix: "Status",
+				WireFormat: WireFormatName,
+			},
+		},
+		{
+			name:          "invalid wireformat flag",
+			comment:       `//go:generate genum -type=Status -wireformat=weird`,
+			sourceFile:    "status.go",
+			expected:      nil,
+			expectedError: "invalid argument -wireformat: weird",
+		},
+		{
+			name:       "valid comment with kind=flags",
+			comment:    `//go:generate genum -type=Permission -kind=flags`,
+			sourceFile: "permission.go",
+			expected: &Directive{
+				TypeName:   "Permission",
+				OutputFile: "permission_genum.go",
+				TrimPrefix: "Permission",
+				Kind:       KindFlags,
+			},
+		},
+		{
+			name:          "invalid kind flag",
+			comment:       `//go:generate genum -type=Status -kind=weird`,
+			sourceFile:    "status.go",
+			expected:      nil,
+			expectedError: "invalid argument -kind: weird",
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +231,17 @@ func TestParseFromComment(t *testing.T) {
 				assert.Equal(t, tt.expected.TypeName, result.TypeName)
 				assert.Equal(t, tt.expected.OutputFile, result.OutputFile)
 				assert.Equal(t, tt.expected.TrimPrefix, result.TrimPrefix)
+				assert.Equal(t, tt.expected.Marshal, result.Marshal)
+				if tt.expected.WireFormat != "" {
+					assert.Equal(t, tt.expected.WireFormat, result.WireFormat)
+				} else {
+					assert.Equal(t, WireFormatTrimmed, result.WireFormat)
+				}
+				if tt.expected.Kind != "" {
+					assert.Equal(t, tt.expected.Kind, result.Kind)
+				} else {
+					assert.Equal(t, KindEnum, result.Kind)
+				}
 			}
 		})
 	}
@@ -0,0 +1,157 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const exhaustiveDirective = "//genum:exhaustive"
+
+var generatedFileRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// Diagnostic describes a switch statement missing one or more enum cases.
+type Diagnostic struct {
+	Pos     token.Position
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Pos, d.Message)
+}
+
+// Checker walks a package's syntax trees looking for switch statements over
+// enum types registered in a Registry and reports missing cases.
+type Checker struct {
+	Registry *Registry
+
+	// IncludeGenerated also checks generated files (those carrying the
+	// standard "Code generated ... DO NOT EDIT." header), which are skipped
+	// by default.
+	IncludeGenerated bool
+}
+
+func NewChecker(registry *Registry) *Checker {
+	return &Checker{Registry: registry}
+}
+
+// Check walks pkg's syntax trees and returns one Diagnostic per switch
+// statement that does not cover every member of its enum type.
+func (c *Checker) Check(pkg *packages.Package) []Diagnostic {
+	var diags []Diagnostic
+
+	for _, file := range pkg.Syntax {
+		if !c.IncludeGenerated && isGeneratedFile(file) {
+			continue
+		}
+
+		cmap := ast.NewCommentMap(pkg.Fset, file, file.Comments)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			sw, ok := n.(*ast.SwitchStmt)
+			if !ok || sw.Tag == nil {
+				return true
+			}
+
+			tagType := pkg.TypesInfo.Types[sw.Tag].Type
+			if tagType == nil {
+				return true
+			}
+
+			members, ok := c.Registry.Lookup(tagType)
+			if !ok {
+				return true
+			}
+
+			if diag := c.checkSwitch(pkg, sw, members, hasExhaustiveDirective(cmap[sw])); diag != nil {
+				diags = append(diags, *diag)
+			}
+			return true
+		})
+	}
+
+	return diags
+}
+
+func (c *Checker) checkSwitch(pkg *packages.Package, sw *ast.SwitchStmt, members map[string]struct{}, strict bool) *Diagnostic {
+	covered := make(map[string]struct{}, len(members))
+	hasDefault := false
+
+	for _, stmt := range sw.Body.List {
+		cc, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		if cc.List == nil {
+			hasDefault = true
+			continue
+		}
+		for _, expr := range cc.List {
+			if name, ok := memberName(pkg, expr); ok {
+				covered[name] = struct{}{}
+			}
+		}
+	}
+
+	if hasDefault && !strict {
+		return nil
+	}
+
+	var missing []string
+	for name := range members {
+		if _, ok := covered[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+
+	tagType := pkg.TypesInfo.Types[sw.Tag].Type
+	return &Diagnostic{
+		Pos:     pkg.Fset.Position(sw.Pos()),
+		Message: fmt.Sprintf("missing cases in switch on %s: %s", tagType, strings.Join(missing, ", ")),
+	}
+}
+
+func memberName(pkg *packages.Package, expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if obj := pkg.TypesInfo.ObjectOf(e); obj != nil {
+			return obj.Name(), true
+		}
+	case *ast.SelectorExpr:
+		if obj := pkg.TypesInfo.ObjectOf(e.Sel); obj != nil {
+			return obj.Name(), true
+		}
+	}
+	return "", false
+}
+
+func hasExhaustiveDirective(groups []*ast.CommentGroup) bool {
+	for _, group := range groups {
+		for _, comment := range group.List {
+			if strings.HasPrefix(strings.TrimSpace(comment.Text), exhaustiveDirective) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isGeneratedFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if generatedFileRe.MatchString(strings.TrimSpace(comment.Text)) {
+				return true
+			}
+		}
+	}
+	return false
+}
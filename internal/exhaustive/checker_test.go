@@ -0,0 +1,213 @@
+package exhaustive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadFixture writes a minimal module containing src to a temp directory
+// and loads it via packages.Load, the same entry point the generator and
+// the check subcommand use against real packages.
+func loadFixture(t *testing.T, src string) *packages.Package {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fixture.go"), []byte(src), 0644))
+
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	return pkgs[0]
+}
+
+const fixtureSrc = `package fixture
+
+//go:generate genum -type=Status
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+	StatusPending
+)
+
+func describe(s Status) string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusInactive:
+		return "inactive"
+	}
+	return ""
+}
+`
+
+func TestChecker_ReportsMissingCases(t *testing.T) {
+	pkg := loadFixture(t, fixtureSrc)
+
+	registry, err := Build(pkg)
+	require.NoError(t, err)
+
+	checker := NewChecker(registry)
+	diags := checker.Check(pkg)
+
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Message, "StatusPending")
+}
+
+const fixtureSrcWithDefault = `package fixture
+
+//go:generate genum -type=Status
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+
+func describe(s Status) string {
+	switch s {
+	case StatusActive:
+		return "active"
+	default:
+		return "other"
+	}
+}
+`
+
+func TestChecker_DefaultClauseSatisfiesSwitch(t *testing.T) {
+	pkg := loadFixture(t, fixtureSrcWithDefault)
+
+	registry, err := Build(pkg)
+	require.NoError(t, err)
+
+	checker := NewChecker(registry)
+	diags := checker.Check(pkg)
+
+	require.Empty(t, diags)
+}
+
+const fixtureSrcStrictDefault = `package fixture
+
+//go:generate genum -type=Status
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+
+func describe(s Status) string {
+	//genum:exhaustive
+	switch s {
+	case StatusActive:
+		return "active"
+	default:
+		return "other"
+	}
+}
+`
+
+func TestChecker_ExhaustiveDirectiveRejectsDefault(t *testing.T) {
+	pkg := loadFixture(t, fixtureSrcStrictDefault)
+
+	registry, err := Build(pkg)
+	require.NoError(t, err)
+
+	checker := NewChecker(registry)
+	diags := checker.Check(pkg)
+
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Message, "StatusInactive")
+}
+
+func TestChecker_IgnoresSwitchesOnOtherTypes(t *testing.T) {
+	pkg := loadFixture(t, `package fixture
+
+//go:generate genum -type=Status
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+
+func describe(n int) string {
+	switch n {
+	case 1:
+		return "one"
+	}
+	return ""
+}
+`)
+
+	registry, err := Build(pkg)
+	require.NoError(t, err)
+
+	checker := NewChecker(registry)
+	diags := checker.Check(pkg)
+
+	require.Empty(t, diags)
+}
+
+const fixtureSrcGenerated = `// Code generated by genum. DO NOT EDIT.
+
+package fixture
+
+//go:generate genum -type=Status
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+
+func describe(s Status) string {
+	switch s {
+	case StatusActive:
+		return "active"
+	}
+	return ""
+}
+`
+
+func TestChecker_SkipsGeneratedFilesByDefault(t *testing.T) {
+	pkg := loadFixture(t, fixtureSrcGenerated)
+
+	registry, err := Build(pkg)
+	require.NoError(t, err)
+
+	checker := NewChecker(registry)
+	diags := checker.Check(pkg)
+
+	require.Empty(t, diags)
+}
+
+func TestChecker_IncludeGeneratedChecksGeneratedFiles(t *testing.T) {
+	pkg := loadFixture(t, fixtureSrcGenerated)
+
+	registry, err := Build(pkg)
+	require.NoError(t, err)
+
+	checker := NewChecker(registry)
+	checker.IncludeGenerated = true
+	diags := checker.Check(pkg)
+
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Message, "StatusInactive")
+}
@@ -0,0 +1,111 @@
+package exhaustive
+
+import (
+	"fmt"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sv3tluv/genum/internal"
+)
+
+// Registry maps the named types produced by genum directives to their
+// declared member names, so the checker can recognize enum-typed switches.
+type Registry struct {
+	enums map[*types.Named]map[string]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{enums: make(map[*types.Named]map[string]struct{})}
+}
+
+// Add registers typeName (declared in pkg) together with its enum members.
+func (r *Registry) Add(pkg *packages.Package, typeName string, values []internal.EnumValue) {
+	obj := pkg.Types.Scope().Lookup(typeName)
+	if obj == nil {
+		return
+	}
+	typeNameObj, ok := obj.(*types.TypeName)
+	if !ok {
+		return
+	}
+	named, ok := typeNameObj.Type().(*types.Named)
+	if !ok {
+		return
+	}
+
+	members := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		members[v.Name] = struct{}{}
+	}
+	r.enums[named] = members
+}
+
+// Lookup returns the registered member names for typ, if typ was registered
+// via Add.
+func (r *Registry) Lookup(typ types.Type) (map[string]struct{}, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	members, ok := r.enums[named]
+	return members, ok
+}
+
+// Build parses every genum directive found in pkg's source files and
+// registers the resulting enums.
+//
+// A single package's registry only recognizes enums declared in that same
+// package; a switch in one package over an enum type imported from another
+// is invisible to it. BuildAll builds one registry across every package the
+// checker walks so cross-package switches are still caught.
+func Build(pkg *packages.Package) (*Registry, error) {
+	registry := NewRegistry()
+	if err := addPackage(registry, pkg); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// BuildAll builds a single Registry spanning every package in pkgs, so a
+// switch in one package over an enum type declared in another still
+// resolves against the enum's registered members.
+func BuildAll(pkgs []*packages.Package) (*Registry, error) {
+	registry := NewRegistry()
+	for _, pkg := range pkgs {
+		if err := addPackage(registry, pkg); err != nil {
+			return nil, fmt.Errorf("build registry for %s: %w", pkg.PkgPath, err)
+		}
+	}
+	return registry, nil
+}
+
+func addPackage(registry *Registry, pkg *packages.Package) error {
+	parser := internal.NewParser()
+
+	for _, file := range pkg.Syntax {
+		env := &internal.Environment{
+			Pkg:            pkg,
+			SourceFile:     file,
+			SourceFileName: filepath.Base(pkg.Fset.Position(file.Pos()).Filename),
+		}
+
+		directives, err := parser.ParseFileDirectives(env)
+		if err != nil {
+			return err
+		}
+
+		for _, directive := range directives {
+			enum, err := parser.ParseSingleEnum(pkg, &directive)
+			if err != nil {
+				// An enum that fails to parse is left out of the registry
+				// rather than failing the check; the check does not report it.
+				continue
+			}
+			registry.Add(pkg, directive.TypeName, enum.Values)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package exhaustive
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadModule writes a small module with one file per path => source and
+// loads every package in it, the same way Run does for "./...".
+func loadModule(t *testing.T, files map[string]string) []*packages.Package {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.21\n"), 0644))
+	for path, src := range files {
+		full := filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+		require.NoError(t, os.WriteFile(full, []byte(src), 0644))
+	}
+
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	require.NoError(t, err)
+	for _, pkg := range pkgs {
+		require.Emptyf(t, pkg.Errors, "%s: %v", pkg.PkgPath, pkg.Errors)
+	}
+
+	return pkgs
+}
+
+// TestBuildAll_ChecksSwitchOverImportedEnum verifies that a switch in one
+// package over an enum type declared in another package is still checked,
+// which requires the registry to be built across every loaded package
+// rather than per-package.
+func TestBuildAll_ChecksSwitchOverImportedEnum(t *testing.T) {
+	pkgs := loadModule(t, map[string]string{
+		"status/status.go": `package status
+
+//go:generate genum -type=Status
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+	StatusPending
+)
+`,
+		"consumer/consumer.go": `package consumer
+
+import "fixture/status"
+
+func describe(s status.Status) string {
+	switch s {
+	case status.StatusActive:
+		return "active"
+	case status.StatusInactive:
+		return "inactive"
+	}
+	return ""
+}
+`,
+	})
+
+	registry, err := BuildAll(pkgs)
+	require.NoError(t, err)
+
+	var consumer *packages.Package
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == "fixture/consumer" {
+			consumer = pkg
+		}
+	}
+	require.NotNil(t, consumer, "consumer package not loaded")
+
+	checker := NewChecker(registry)
+	diags := checker.Check(consumer)
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Message, "StatusPending")
+}
+
+// TestBuildAll_MergesAcrossPackages exercises BuildAll directly to confirm
+// it registers enums from every package, not just the first one.
+func TestBuildAll_MergesAcrossPackages(t *testing.T) {
+	pkgs := loadModule(t, map[string]string{
+		"a/a.go": `package a
+
+//go:generate genum -type=Kind
+type Kind int
+
+const (
+	KindFirst Kind = iota
+	KindSecond
+)
+`,
+		"b/b.go": `package b
+
+//go:generate genum -type=Role
+type Role int
+
+const (
+	RoleFirst Role = iota
+	RoleSecond
+)
+`,
+	})
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].PkgPath < pkgs[j].PkgPath })
+
+	registry, err := BuildAll(pkgs)
+	require.NoError(t, err)
+	require.Len(t, registry.enums, 2)
+}
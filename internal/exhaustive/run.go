@@ -0,0 +1,51 @@
+package exhaustive
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Run loads every package matched by patterns (defaulting to "./..." when
+// patterns is empty), checks each for non-exhaustive switches over genum
+// enums, and prints one line per finding. It returns the number of findings
+// so callers can translate it into a process exit code.
+//
+// The registry is built once across every loaded package before any package
+// is checked, so a switch in one package over an enum type declared in
+// another is still recognized. includeGenerated, when true, also checks
+// generated files that would otherwise be skipped.
+func Run(patterns []string, includeGenerated bool) (int, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return 0, fmt.Errorf("load packages: %w", err)
+	}
+
+	registry, err := BuildAll(pkgs)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	for _, pkg := range pkgs {
+		checker := NewChecker(registry)
+		checker.IncludeGenerated = includeGenerated
+		for _, diag := range checker.Check(pkg) {
+			fmt.Println(diag)
+			total++
+		}
+	}
+
+	return total, nil
+}
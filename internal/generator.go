@@ -2,7 +2,9 @@ package internal
 
 import (
 	"fmt"
+	"go/format"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -10,15 +12,24 @@ import (
 	"golang.org/x/text/language"
 )
 
+// TemplateData is the per-enum context enumTemplate executes against. A File
+// with several enums renders the header once, then one TemplateData per
+// enum.
 type TemplateData struct {
 	PackageName string
+	Imports     map[string]bool
 	TypeName    string
-	BaseType    string
+	BaseType    BaseType
+	Case        string
+	Marshal     []string
+	WireFormat  string
+	Kind        string
 	Values      []EnumValue
 }
 
 type Generator struct {
-	tmpl *template.Template
+	headerTmpl *template.Template
+	enumTmpl   *template.Template
 }
 
 func NewGenerator() *Generator {
@@ -29,9 +40,36 @@ func NewGenerator() *Generator {
 		"removePrefix": func(typeName, name string) string {
 			return strings.TrimPrefix(name, typeName)
 		},
+		"sortedImports": func(imports map[string]bool) []string {
+			out := make([]string, 0, len(imports))
+			for path, needed := range imports {
+				if needed {
+					out = append(out, path)
+				}
+			}
+			sort.Strings(out)
+			return out
+		},
+		"hasMarshal": func(marshal []string, kind string) bool {
+			for _, m := range marshal {
+				if m == kind {
+					return true
+				}
+			}
+			return false
+		},
+		"zeroFlagName": func(values []EnumValue) string {
+			for _, v := range values {
+				if v.IsNumeric && v.Numeric == 0 {
+					return v.WireName
+				}
+			}
+			return "0"
+		},
 	}
 	return &Generator{
-		tmpl: template.Must(template.New("enum").Funcs(funcMap).Parse(enumTemplate)),
+		headerTmpl: template.Must(template.New("header").Funcs(funcMap).Parse(fileHeaderTemplate)),
+		enumTmpl:   template.Must(template.New("enum").Funcs(funcMap).Parse(enumTemplate)),
 	}
 }
 
@@ -48,9 +86,34 @@ func (g *Generator) Generate(file File) error {
 
 func (g *Generator) GenerateFile(file File) (string, error) {
 	var buf strings.Builder
-	err := g.tmpl.Execute(&buf, file)
-	code := buf.String()
-	return strings.TrimSpace(code), err
+
+	if err := g.headerTmpl.Execute(&buf, file); err != nil {
+		return "", err
+	}
+
+	for _, enum := range file.Enums {
+		data := TemplateData{
+			PackageName: file.Package,
+			Imports:     file.Imports,
+			TypeName:    enum.TypeName,
+			BaseType:    enum.BaseType,
+			Case:        enum.Case,
+			Marshal:     enum.Marshal,
+			WireFormat:  enum.WireFormat,
+			Kind:        enum.Kind,
+			Values:      enum.Values,
+		}
+		if err := g.enumTmpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+	}
+
+	formatted, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return "", fmt.Errorf("format generated code: %w", err)
+	}
+
+	return strings.TrimSpace(string(formatted)), nil
 }
 
 func (g *Generator) WriteFile(filename, content string) error {
@@ -0,0 +1,27 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_GenerateFile_Flags(t *testing.T) {
+	env := loadTestdataEnvironment(t, "flagsenum")
+	code := generateTestdata(t, env)
+	requireValidGo(t, code)
+
+	for _, want := range []string{
+		"func (f Permission) Has(other Permission) bool {",
+		"func (f Permission) Set(other Permission) Permission {",
+		"func (f Permission) Clear(other Permission) Permission {",
+		"func (f Permission) Toggle(other Permission) Permission {",
+		"func (f Permission) String() string {",
+		"func ParsePermission(s string) (Permission, error) {",
+		`"read"`,
+		`"write"`,
+		`"execute"`,
+	} {
+		require.Containsf(t, code, want, "generated code missing %q:\n%s", want, code)
+	}
+}
@@ -0,0 +1,133 @@
+package internal_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sv3tluv/genum/internal"
+)
+
+// loadTestdataEnvironment loads the single-package fixture under
+// internal/testdata/<name> and builds the Environment Parser.Parse expects,
+// the same way go:generate would when invoked with GOFILE=fixture.go from
+// inside that package's directory.
+func loadTestdataEnvironment(t *testing.T, dir string) *internal.Environment {
+	t.Helper()
+
+	cfg := &packages.Config{
+		Dir: "testdata/" + dir,
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	pkg := pkgs[0]
+	for _, file := range pkg.Syntax {
+		if strings.HasSuffix(pkg.Fset.Position(file.Pos()).Filename, "fixture.go") {
+			return &internal.Environment{Pkg: pkg, SourceFile: file, SourceFileName: "fixture.go"}
+		}
+	}
+
+	t.Fatal("fixture.go not found in loaded package")
+	return nil
+}
+
+// generateTestdata runs env through Parser and Generator and returns the
+// generated source for its first output file.
+func generateTestdata(t *testing.T, env *internal.Environment) string {
+	t.Helper()
+
+	files, err := internal.NewParser().Parse(env)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	code, err := internal.NewGenerator().GenerateFile(files[0])
+	require.NoError(t, err)
+	return code
+}
+
+func requireValidGo(t *testing.T, code string) {
+	t.Helper()
+	_, err := parser.ParseFile(token.NewFileSet(), "", code, parser.AllErrors)
+	require.NoErrorf(t, err, "generated code does not parse:\n%s", code)
+}
+
+// TestGenerator_GenerateFile_Marshal covers -marshal against both a string
+// and an integer base type: json+sql+text together on each base (where the
+// default trimmed wire form vs. -wireformat=value diverges between them),
+// and json alone on the string base to confirm the others are left
+// ungenerated. Other subsets of marshalers are not covered here.
+func TestGenerator_GenerateFile_Marshal(t *testing.T) {
+	allMethods := []string{
+		"func (e Status) String() string {",
+		"func (e Status) MarshalJSON() ([]byte, error) {",
+		"func (e *Status) UnmarshalJSON(data []byte) error {",
+		"func (e Status) Value() (driver.Value, error) {",
+		"func (e *Status) Scan(value interface{}) error {",
+		"func (e Status) MarshalText() ([]byte, error) {",
+		"func (e *Status) UnmarshalText(text []byte) error {",
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		want    []string
+		wantNot []string
+		wire    []string
+	}{
+		{
+			name: "string base, default wireformat, json+sql+text",
+			dir:  "marshalenum",
+			want: allMethods,
+			wire: []string{`"active"`, `"paused"`},
+		},
+		{
+			name: "integer base, wireformat=value, json+sql+text",
+			dir:  "marshalenum_int",
+			want: allMethods,
+			wire: []string{`"1"`, `"2"`},
+		},
+		{
+			name: "string base, json only",
+			dir:  "marshalenum_jsononly",
+			want: []string{
+				"func (e Status) String() string {",
+				"func (e Status) MarshalJSON() ([]byte, error) {",
+				"func (e *Status) UnmarshalJSON(data []byte) error {",
+			},
+			wantNot: []string{
+				"func (e Status) Value() (driver.Value, error) {",
+				"func (e *Status) Scan(value interface{}) error {",
+				"func (e Status) MarshalText() ([]byte, error) {",
+				"func (e *Status) UnmarshalText(text []byte) error {",
+			},
+			wire: []string{`"active"`, `"paused"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := loadTestdataEnvironment(t, tt.dir)
+			code := generateTestdata(t, env)
+			requireValidGo(t, code)
+
+			for _, want := range append(append([]string{}, tt.want...), tt.wire...) {
+				require.Containsf(t, code, want, "generated code missing %q:\n%s", want, code)
+			}
+			for _, notWant := range tt.wantNot {
+				require.NotContainsf(t, code, notWant, "generated code unexpectedly has %q:\n%s", notWant, code)
+			}
+		})
+	}
+}
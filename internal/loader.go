@@ -5,14 +5,22 @@ import (
 	"go/ast"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"golang.org/x/tools/go/packages"
+
+	"github.com/sv3tluv/genum/internal/config"
 )
 
 type Environment struct {
 	Pkg            *packages.Package
 	SourceFile     *ast.File
 	SourceFileName string
+
+	// Directives, when non-nil, are used in place of scanning SourceFile for
+	// //go:generate comments. Environments produced from a project config
+	// file set this; the classic GOFILE-driven environment leaves it nil.
+	Directives []Directive
 }
 
 func (e *Environment) PackageName() string {
@@ -35,8 +43,25 @@ func NewLoader() *Loader {
 	}
 }
 
-func (l *Loader) Load() (*Environment, error) {
-	pkg, err := l.loadPackage()
+// Load returns one Environment per package that needs code generated. When
+// a .genum.yaml project config is present at the module root it drives
+// every enum it lists, batching packages.Load once across the packages it
+// references; otherwise Load falls back to the classic //go:generate flow,
+// synthesizing a single-entry result from GOFILE.
+func (l *Loader) Load() ([]Environment, error) {
+	if path, ok := config.Find("."); ok {
+		return l.loadFromConfig(path)
+	}
+
+	env, err := l.loadFromDirective()
+	if err != nil {
+		return nil, err
+	}
+	return []Environment{*env}, nil
+}
+
+func (l *Loader) loadFromDirective() (*Environment, error) {
+	pkg, err := l.loadPackage(".")
 	if err != nil {
 		return nil, err
 	}
@@ -53,15 +78,77 @@ func (l *Loader) Load() (*Environment, error) {
 	}, nil
 }
 
-func (l *Loader) loadPackage() (*packages.Package, error) {
-	pkgs, err := packages.Load(l.config, ".")
+func (l *Loader) loadFromConfig(path string) ([]Environment, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	byPackage := make(map[string][]config.EnumConfig)
+	for _, enum := range cfg.Enums {
+		if _, ok := byPackage[enum.Package]; !ok {
+			order = append(order, enum.Package)
+		}
+		byPackage[enum.Package] = append(byPackage[enum.Package], enum)
+	}
+
+	// Package patterns in the config (e.g. "./pkg/status") are relative to
+	// the config file's directory, not the process's cwd: config.Find walks
+	// up from the invoking package to the module root, so those two
+	// directories are frequently different.
+	l.config.Dir = filepath.Dir(path)
+
+	pkgs, err := l.loadPackages(order)
+	if err != nil {
+		return nil, err
+	}
+
+	envs := make([]Environment, 0, len(pkgs))
+	for i, pkg := range pkgs {
+		enums := byPackage[order[i]]
+		directives := make([]Directive, 0, len(enums))
+		for _, enum := range enums {
+			directive := directiveFromEnumConfig(enum)
+			// OutputFile is a bare filename (e.g. "status_genum.go"); join it
+			// with the package's own directory so the generator writes next
+			// to the enum's source rather than into the process's cwd.
+			if len(pkg.GoFiles) > 0 {
+				directive.OutputFile = filepath.Join(filepath.Dir(pkg.GoFiles[0]), directive.OutputFile)
+			}
+			directives = append(directives, directive)
+		}
+		envs = append(envs, Environment{
+			Pkg:        pkg,
+			Directives: directives,
+		})
+	}
+
+	return envs, nil
+}
+
+func (l *Loader) loadPackage(pattern string) (*packages.Package, error) {
+	pkgs, err := l.loadPackages([]string{pattern})
+	if err != nil {
+		return nil, err
+	}
+	return pkgs[0], nil
+}
+
+// loadPackages loads every pattern in a single packages.Load call so a
+// project config referencing many packages doesn't pay per-enum load cost.
+func (l *Loader) loadPackages(patterns []string) ([]*packages.Package, error) {
+	pkgs, err := packages.Load(l.config, patterns...)
 	if err != nil {
 		return nil, err
 	}
 	if len(pkgs) == 0 {
 		return nil, fmt.Errorf("package not found")
 	}
-	return pkgs[0], nil
+	if len(pkgs) != len(patterns) {
+		return nil, fmt.Errorf("expected %d package(s), got %d", len(patterns), len(pkgs))
+	}
+	return pkgs, nil
 }
 
 func (l *Loader) loadSourceFile(pkg *packages.Package) (*ast.File, string, error) {
@@ -74,3 +161,35 @@ func (l *Loader) loadSourceFile(pkg *packages.Package) (*ast.File, string, error
 	}
 	return nil, "", fmt.Errorf("%s not find in package %s", sourceFileName, pkg.Name)
 }
+
+func directiveFromEnumConfig(e config.EnumConfig) Directive {
+	d := Directive{
+		TypeName:   e.Type,
+		OutputFile: e.Output,
+		TrimPrefix: e.TrimPrefix,
+		Case:       CaseHandling(e.Case),
+		Kind:       EnumKind(e.Kind),
+		WireFormat: WireFormat(e.WireFormat),
+	}
+	for _, m := range e.Marshal {
+		d.Marshal = append(d.Marshal, MarshalKind(m))
+	}
+
+	if d.TrimPrefix == "" {
+		d.TrimPrefix = d.TypeName
+	}
+	if d.Case == "" {
+		d.Case = CaseSensitive
+	}
+	if d.Kind == "" {
+		d.Kind = KindEnum
+	}
+	if d.WireFormat == "" {
+		d.WireFormat = WireFormatTrimmed
+	}
+	if d.OutputFile == "" {
+		d.OutputFile = strings.ToLower(d.TypeName) + "_genum.go"
+	}
+
+	return d
+}
@@ -0,0 +1,88 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv3tluv/genum/internal"
+)
+
+// chdir switches the process's cwd to dir for the duration of the test,
+// restoring the original on cleanup. Loader.Load and config.Find both key
+// off the cwd, the same way go:generate does.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	original, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(original))
+	})
+}
+
+// TestLoader_LoadFromConfig_ResolvesPackagesFromConfigDir builds a module
+// whose .genum.yaml lives at the root but is invoked (as go:generate would)
+// from an unrelated subpackage, and checks that the package patterns in the
+// config resolve relative to the config file's directory rather than the
+// cwd genum happened to start from.
+func TestLoader_LoadFromConfig_ResolvesPackagesFromConfigDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".genum.yaml"), []byte(`
+enums:
+  - package: ./pkg/status
+    type: Status
+    trimprefix: Status
+    marshal: [json]
+`), 0644))
+
+	statusDir := filepath.Join(root, "pkg", "status")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "status.go"), []byte(`package status
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusPaused Status = "paused"
+)
+`), 0644))
+
+	// Simulate go:generate running from a package directory that is not
+	// the module root and does not itself contain the config.
+	cmdDir := filepath.Join(root, "cmd", "server")
+	require.NoError(t, os.MkdirAll(cmdDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+
+	chdir(t, cmdDir)
+
+	loader := internal.NewLoader()
+	envs, err := loader.Load()
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Equal(t, "status", envs[0].PackageName())
+	require.Len(t, envs[0].Directives, 1)
+	require.Equal(t, "Status", envs[0].Directives[0].TypeName)
+
+	parser := internal.NewParser()
+	files, err := parser.Parse(&envs[0])
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Len(t, files[0].Enums, 1)
+	require.Len(t, files[0].Enums[0].Values, 2)
+
+	// The directive's OutputFile must already be joined with the package's
+	// directory: Generator.WriteFile writes it as-is, relative to the
+	// process's cwd (still cmdDir here), not the target package.
+	require.Equal(t, filepath.Join(statusDir, "status_genum.go"), files[0].Output)
+
+	require.NoError(t, internal.NewGenerator().Generate(files[0]))
+	written := filepath.Join(statusDir, "status_genum.go")
+	content, err := os.ReadFile(written)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "package status")
+	require.NoFileExists(t, filepath.Join(cmdDir, "status_genum.go"))
+}
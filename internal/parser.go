@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
 	"strings"
@@ -16,20 +17,37 @@ type File struct {
 	Output  string
 	Enums   []Enum
 
-	NeedStringsPackage bool
+	// Imports is the set of packages the generated file needs to import,
+	// keyed by import path. It is accumulated per enum as enums are added
+	// to the file.
+	Imports map[string]bool
 }
 
 type Enum struct {
 	TypeName   string
-	BaseType   string
+	BaseType   BaseType
 	TrimPrefix string
 	Case       string
+	Marshal    []string
+	WireFormat string
+	Kind       string
 	Values     []EnumValue
 }
 
 type EnumValue struct {
 	Name  string
 	Value string
+
+	// Numeric and IsNumeric are populated when the constant's value could
+	// be evaluated as a Go integer constant (see Parser.evaluateConst).
+	// -kind=flags relies on these to detect power-of-two membership.
+	Numeric   int64
+	IsNumeric bool
+
+	// WireName is the textual form the generated marshal/parse code reads
+	// and writes, derived from Name according to the enum's WireFormat (see
+	// applyWireNames).
+	WireName string
 }
 
 type Parser struct{}
@@ -39,9 +57,13 @@ func NewParser() *Parser {
 }
 
 func (p *Parser) Parse(env *Environment) ([]File, error) {
-	directives, err := p.ParseFileDirectives(env)
-	if err != nil {
-		return nil, err
+	directives := env.Directives
+	if directives == nil {
+		var err error
+		directives, err = p.ParseFileDirectives(env)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if len(directives) == 0 {
 		return nil, fmt.Errorf("no genum directives found")
@@ -56,24 +78,25 @@ func (p *Parser) Parse(env *Environment) ([]File, error) {
 
 		enum.TrimPrefix = directive.TrimPrefix
 		enum.Case = string(directive.Case)
+		enum.WireFormat = string(directive.WireFormat)
+		for _, m := range directive.Marshal {
+			enum.Marshal = append(enum.Marshal, string(m))
+		}
+		applyWireNames(enum, &directive)
 
 		if _, ok := files[directive.OutputFile]; !ok {
-			file := &File{
+			files[directive.OutputFile] = &File{
 				Package: env.PackageName(),
 				Source:  env.SourceFileName,
 				Output:  directive.OutputFile,
 				Enums:   []Enum{},
+				Imports: make(map[string]bool),
 			}
-
-			if !file.NeedStringsPackage {
-				file.NeedStringsPackage =
-					directive.Case != CaseSensitive &&
-						enum.BaseType == "string"
-			}
-
-			files[directive.OutputFile] = file
 		}
-		files[directive.OutputFile].Enums = append(files[directive.OutputFile].Enums, *enum)
+
+		file := files[directive.OutputFile]
+		file.Enums = append(file.Enums, *enum)
+		addImports(file.Imports, directive.Case, enum)
 	}
 
 	out := make([]File, 0, len(files))
@@ -83,6 +106,56 @@ func (p *Parser) Parse(env *Environment) ([]File, error) {
 	return out, nil
 }
 
+// addImports records the packages the generated code for enum will need,
+// given its case handling, kind, and requested marshalers.
+func addImports(imports map[string]bool, caseHandling CaseHandling, enum *Enum) {
+	// String() always falls back to fmt.Sprintf for an unrecognized value.
+	imports["fmt"] = true
+	// CaseIgnore lowercases the incoming string at lookup time; -kind=flags
+	// always joins/splits its member names on "|". Both need "strings".
+	if caseHandling == CaseIgnore || enum.Kind == string(KindFlags) {
+		imports["strings"] = true
+	}
+	for _, m := range enum.Marshal {
+		switch MarshalKind(m) {
+		case MarshalJSON:
+			imports["encoding/json"] = true
+			imports["fmt"] = true
+		case MarshalSQL:
+			imports["database/sql/driver"] = true
+			imports["fmt"] = true
+		case MarshalText:
+			// encoding.TextMarshaler/TextUnmarshaler need no extra imports.
+		}
+	}
+}
+
+// applyWireNames fills each value's WireName according to directive's
+// WireFormat: the bare constant name, its literal value, or (the default)
+// the name with TrimPrefix removed and lowercased. CaseLower/CaseUpper then
+// force the result to that case; CaseIgnore leaves WireName as computed and
+// instead makes the generated lookup fold the incoming string at runtime
+// (see enumTemplate's _Lookup helper).
+func applyWireNames(enum *Enum, directive *Directive) {
+	for i := range enum.Values {
+		v := &enum.Values[i]
+		switch directive.WireFormat {
+		case WireFormatName:
+			v.WireName = v.Name
+		case WireFormatValue:
+			v.WireName = v.Value
+		default:
+			v.WireName = strings.ToLower(strings.TrimPrefix(v.Name, directive.TrimPrefix))
+		}
+		switch directive.Case {
+		case CaseLower:
+			v.WireName = strings.ToLower(v.WireName)
+		case CaseUpper:
+			v.WireName = strings.ToUpper(v.WireName)
+		}
+	}
+}
+
 func (p *Parser) ParseFileDirectives(env *Environment) ([]Directive, error) {
 	var directives []Directive
 	ast.Inspect(env.SourceFile, func(n ast.Node) bool {
@@ -105,7 +178,7 @@ func (p *Parser) ParseFileDirectives(env *Environment) ([]Directive, error) {
 
 func (p *Parser) ParseSingleEnum(pkg *packages.Package, directive *Directive) (*Enum, error) {
 	baseType := p.ParseBaseType(pkg, directive.TypeName)
-	if baseType == nil || *baseType == "" {
+	if baseType == nil {
 		return nil, fmt.Errorf("type %s not found", directive.TypeName)
 	}
 
@@ -114,11 +187,53 @@ func (p *Parser) ParseSingleEnum(pkg *packages.Package, directive *Directive) (*
 		return nil, fmt.Errorf("no values found for enum %s", directive.TypeName)
 	}
 
-	return &Enum{
+	enum := &Enum{
 		TypeName: directive.TypeName,
 		BaseType: *baseType,
+		Kind:     string(directive.Kind),
 		Values:   values,
-	}, nil
+	}
+
+	if directive.Kind == KindFlags {
+		if err := p.validateFlags(enum); err != nil {
+			return nil, err
+		}
+	}
+
+	return enum, nil
+}
+
+// validateFlags checks that enum is usable with -kind=flags: an integer
+// base type, no duplicate values, and every nonzero value a power of two
+// unless its name carries the conventional _MASK suffix for composite flags.
+func (p *Parser) validateFlags(enum *Enum) error {
+	if !enum.BaseType.IsInteger() {
+		return fmt.Errorf("-kind=flags requires an integer base type, got %s", enum.BaseType)
+	}
+
+	seen := make(map[int64]string, len(enum.Values))
+	for _, v := range enum.Values {
+		if !v.IsNumeric {
+			return fmt.Errorf("-kind=flags: %s is not a constant integer value", v.Name)
+		}
+		if existing, ok := seen[v.Numeric]; ok {
+			return fmt.Errorf("-kind=flags: %s and %s share the value %d", existing, v.Name, v.Numeric)
+		}
+		seen[v.Numeric] = v.Name
+
+		if v.Numeric == 0 || strings.HasSuffix(v.Name, "_MASK") {
+			continue
+		}
+		if !isPowerOfTwo(v.Numeric) {
+			return fmt.Errorf("-kind=flags: %s (%d) is not a power of two; name composite values with a _MASK suffix", v.Name, v.Numeric)
+		}
+	}
+
+	return nil
+}
+
+func isPowerOfTwo(n int64) bool {
+	return n > 0 && n&(n-1) == 0
 }
 
 func (p *Parser) ParseConstants(pkg *packages.Package, typeName string) []EnumValue {
@@ -130,7 +245,7 @@ func (p *Parser) ParseConstants(pkg *packages.Package, typeName string) []EnumVa
 			switch node := n.(type) {
 			case *ast.GenDecl:
 				if node.Tok == token.CONST {
-					currentType = p.ProcessConstGroup(node, typeName, &values, currentType)
+					currentType = p.ProcessConstGroup(pkg, node, typeName, &values, currentType)
 				}
 			}
 			return true
@@ -140,7 +255,7 @@ func (p *Parser) ParseConstants(pkg *packages.Package, typeName string) []EnumVa
 	return values
 }
 
-func (p *Parser) ProcessConstGroup(decl *ast.GenDecl, targetType string, values *[]EnumValue, lastType string) string {
+func (p *Parser) ProcessConstGroup(pkg *packages.Package, decl *ast.GenDecl, targetType string, values *[]EnumValue, lastType string) string {
 	currentType := lastType
 
 	for _, spec := range decl.Specs {
@@ -148,10 +263,17 @@ func (p *Parser) ProcessConstGroup(decl *ast.GenDecl, targetType string, values
 		if !ok || len(valueSpec.Names) == 0 {
 			continue
 		}
+		// A spec either omits Values (inherits the previous spec's, as with
+		// bare `iota` continuations) or supplies exactly one per name, as in
+		// `A, B Status = "a", "b"`. Anything else can't be matched up and is
+		// skipped rather than risk reading past the end of Values.
+		if len(valueSpec.Values) != 0 && len(valueSpec.Values) != len(valueSpec.Names) {
+			continue
+		}
 
 		if valueSpec.Type != nil {
-			if ident, ok := valueSpec.Type.(*ast.Ident); ok {
-				currentType = ident.Name
+			if name := typeNameFromExpr(valueSpec.Type); name != "" {
+				currentType = name
 			}
 		} else if len(valueSpec.Values) > 0 {
 			currentType = p.ExtractTypeFromValue(valueSpec.Values[0])
@@ -164,9 +286,12 @@ func (p *Parser) ProcessConstGroup(decl *ast.GenDecl, targetType string, values
 			for i, name := range valueSpec.Names {
 				if ast.IsExported(name.Name) {
 					value := p.ExtractValue(valueSpec, i)
+					numeric, isNumeric := p.evaluateConst(pkg, name)
 					*values = append(*values, EnumValue{
-						Name:  name.Name,
-						Value: value,
+						Name:      name.Name,
+						Value:     value,
+						Numeric:   numeric,
+						IsNumeric: isNumeric,
 					})
 				}
 			}
@@ -176,6 +301,46 @@ func (p *Parser) ProcessConstGroup(decl *ast.GenDecl, targetType string, values
 	return currentType
 }
 
+// evaluateConst resolves name's declared value through the type checker so
+// callers can work with the real numeric constant rather than its source
+// text. It returns ok=false for non-integer or unresolvable constants.
+func (p *Parser) evaluateConst(pkg *packages.Package, name *ast.Ident) (int64, bool) {
+	if pkg.TypesInfo == nil {
+		return 0, false
+	}
+
+	obj, ok := pkg.TypesInfo.Defs[name].(*types.Const)
+	if !ok {
+		return 0, false
+	}
+
+	val := obj.Val()
+	if val.Kind() != constant.Int {
+		return 0, false
+	}
+
+	return constant.Int64Val(val)
+}
+
+// typeNameFromExpr returns the declared type name from a const ValueSpec's
+// Type expression. A generic enum instantiates its type, e.g.
+// `StatusActive Status[int] = iota`, which parses as an *ast.IndexExpr (or
+// *ast.IndexListExpr for more than one type argument) rather than the bare
+// *ast.Ident a non-generic declaration produces; both unwrap to the same
+// base type name.
+func typeNameFromExpr(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return typeNameFromExpr(t.X)
+	case *ast.IndexListExpr:
+		return typeNameFromExpr(t.X)
+	default:
+		return ""
+	}
+}
+
 func (p *Parser) ExtractTypeFromValue(expr ast.Expr) string {
 	switch v := expr.(type) {
 	case *ast.CallExpr:
@@ -209,7 +374,7 @@ func (p *Parser) extractValueFromExpr(expr ast.Expr, defaultValue string) string
 	}
 }
 
-func (p *Parser) ParseBaseType(pkg *packages.Package, typeName string) *string {
+func (p *Parser) ParseBaseType(pkg *packages.Package, typeName string) *BaseType {
 	if pkg.TypesInfo == nil {
 		return nil
 	}
@@ -224,21 +389,6 @@ func (p *Parser) ParseBaseType(pkg *packages.Package, typeName string) *string {
 		return nil
 	}
 
-	baseType := p.TypeString(typeNameObj.Type())
+	baseType := resolveBaseType(typeNameObj.Type())
 	return &baseType
 }
-
-func (p *Parser) TypeString(typ types.Type) string {
-	switch t := typ.(type) {
-	case *types.Basic:
-		return t.Name()
-	case *types.Named:
-		return p.TypeString(t.Underlying())
-	case *types.Pointer:
-		return "*" + p.TypeString(t.Elem())
-	case *types.Struct:
-		return "struct{}"
-	}
-
-	return "unsupported"
-}
@@ -0,0 +1,156 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/sv3tluv/genum/internal"
+)
+
+// loadFixturePackage writes a minimal module containing src to a temp
+// directory and loads it via packages.Load, the same entry point the
+// generator uses against real packages.
+func loadFixturePackage(t *testing.T, src string) *packages.Package {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fixture\n\ngo 1.21\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fixture.go"), []byte(src), 0644))
+
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedTypes |
+			packages.NeedTypesInfo |
+			packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Empty(t, pkgs[0].Errors)
+
+	return pkgs[0]
+}
+
+func TestParser_ParseSingleEnum_Flags(t *testing.T) {
+	tests := []struct {
+		name          string
+		src           string
+		expectedError string
+	}{
+		{
+			name: "valid power-of-two flags",
+			src: `package fixture
+
+type Permission uint8
+
+const (
+	PermissionRead Permission = 1 << iota
+	PermissionWrite
+	PermissionExecute
+)
+`,
+		},
+		{
+			name: "mask suffix allows composite value",
+			src: `package fixture
+
+type Permission uint8
+
+const (
+	PermissionRead  Permission = 1 << iota
+	PermissionWrite
+	PermissionExecute
+	PermissionReadWrite_MASK Permission = PermissionRead | PermissionWrite
+)
+`,
+		},
+		{
+			name: "non-power-of-two value rejected",
+			src: `package fixture
+
+type Permission uint8
+
+const (
+	PermissionRead  Permission = 1
+	PermissionWrite Permission = 3
+)
+`,
+			expectedError: "not a power of two",
+		},
+		{
+			name: "duplicate values rejected",
+			src: `package fixture
+
+type Permission uint8
+
+const (
+	PermissionRead  Permission = 1
+	PermissionWrite Permission = 1
+)
+`,
+			expectedError: "share the value",
+		},
+		{
+			name: "non-integer base type rejected",
+			src: `package fixture
+
+type Permission string
+
+const (
+	PermissionRead  Permission = "read"
+	PermissionWrite Permission = "write"
+)
+`,
+			expectedError: "requires an integer base type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := loadFixturePackage(t, tt.src)
+
+			parser := internal.NewParser()
+			enum, err := parser.ParseSingleEnum(pkg, &internal.Directive{
+				TypeName: "Permission",
+				Kind:     internal.KindFlags,
+			})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "flags", enum.Kind)
+		})
+	}
+}
+
+// TestParser_ParseSingleEnum_GenericInstantiatedConst covers a generic enum
+// declaration whose constants name the instantiated type (Status[int]
+// rather than the bare Status), which parses as an *ast.IndexExpr.
+func TestParser_ParseSingleEnum_GenericInstantiatedConst(t *testing.T) {
+	pkg := loadFixturePackage(t, `package fixture
+
+type Status[T ~int] int
+
+const (
+	StatusActive Status[int] = iota
+	StatusInactive
+)
+`)
+
+	parser := internal.NewParser()
+	enum, err := parser.ParseSingleEnum(pkg, &internal.Directive{TypeName: "Status"})
+	require.NoError(t, err)
+	require.Len(t, enum.Values, 2)
+	require.Equal(t, "StatusActive", enum.Values[0].Name)
+	require.Equal(t, "StatusInactive", enum.Values[1].Name)
+}
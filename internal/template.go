@@ -0,0 +1,148 @@
+package internal
+
+// fileHeaderTemplate renders the package clause and import block shared by
+// every enum in a File. It executes once per output file, against the File
+// itself.
+const fileHeaderTemplate = `// Code generated by genum. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{range sortedImports .Imports}}	"{{.}}"
+{{end}})
+{{end}}
+`
+
+// enumTemplate renders the methods for a single enum. It executes once per
+// enum against a TemplateData, and its output is concatenated after
+// fileHeaderTemplate's.
+const enumTemplate = `
+var _{{.TypeName}}Names = map[{{.TypeName}}]string{
+{{range .Values}}	{{.Name}}: "{{.WireName}}",
+{{end}}}
+
+var _{{.TypeName}}Values = map[string]{{.TypeName}}{
+{{range .Values}}	"{{if eq $.Case "ignore"}}{{lower .WireName}}{{else}}{{.WireName}}{{end}}": {{.Name}},
+{{end}}}
+
+func _{{.TypeName}}Lookup(s string) ({{.TypeName}}, bool) {
+{{if eq .Case "ignore"}}	s = strings.ToLower(s)
+{{end}}	v, ok := _{{.TypeName}}Values[s]
+	return v, ok
+}
+
+{{if eq .Kind "flags"}}
+func (f {{.TypeName}}) Has(other {{.TypeName}}) bool {
+	return f&other == other
+}
+
+func (f {{.TypeName}}) Set(other {{.TypeName}}) {{.TypeName}} {
+	return f | other
+}
+
+func (f {{.TypeName}}) Clear(other {{.TypeName}}) {{.TypeName}} {
+	return f &^ other
+}
+
+func (f {{.TypeName}}) Toggle(other {{.TypeName}}) {{.TypeName}} {
+	return f ^ other
+}
+
+func (f {{.TypeName}}) String() string {
+	if f == 0 {
+		return "{{zeroFlagName .Values}}"
+	}
+
+	var names []string
+	remaining := f
+{{range .Values}}{{if ne .Numeric 0}}	if remaining&{{.Name}} == {{.Name}} {
+		names = append(names, "{{.WireName}}")
+		remaining &^= {{.Name}}
+	}
+{{end}}{{end}}	if remaining != 0 {
+		names = append(names, fmt.Sprintf("{{.TypeName}}(0x%x)", {{.BaseType.Name}}(remaining)))
+	}
+	return strings.Join(names, "|")
+}
+
+func Parse{{.TypeName}}(s string) ({{.TypeName}}, error) {
+	var result {{.TypeName}}
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+{{if eq .Case "ignore"}}		part = strings.ToLower(part)
+{{end}}		v, ok := _{{.TypeName}}Values[part]
+		if !ok {
+			return 0, fmt.Errorf("invalid {{.TypeName}} flag: %q", part)
+		}
+		result |= v
+	}
+	return result, nil
+}
+{{else}}
+func (e {{.TypeName}}) String() string {
+	if s, ok := _{{.TypeName}}Names[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("{{.TypeName}}(%v)", {{.BaseType.Name}}(e))
+}
+{{end}}
+
+{{if hasMarshal .Marshal "json"}}
+func (e {{.TypeName}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+func (e *{{.TypeName}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, ok := _{{.TypeName}}Lookup(s)
+	if !ok {
+		return fmt.Errorf("invalid {{.TypeName}}: %q", s)
+	}
+	*e = v
+	return nil
+}
+{{end}}
+{{if hasMarshal .Marshal "sql"}}
+func (e {{.TypeName}}) Value() (driver.Value, error) {
+	return e.String(), nil
+}
+
+func (e *{{.TypeName}}) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into {{.TypeName}}", value)
+	}
+
+	parsed, ok := _{{.TypeName}}Lookup(s)
+	if !ok {
+		return fmt.Errorf("invalid {{.TypeName}}: %q", s)
+	}
+	*e = parsed
+	return nil
+}
+{{end}}
+{{if hasMarshal .Marshal "text"}}
+func (e {{.TypeName}}) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+func (e *{{.TypeName}}) UnmarshalText(text []byte) error {
+	v, ok := _{{.TypeName}}Lookup(string(text))
+	if !ok {
+		return fmt.Errorf("invalid {{.TypeName}}: %q", text)
+	}
+	*e = v
+	return nil
+}
+{{end}}
+`
@@ -0,0 +1,10 @@
+package flagsenum
+
+//go:generate genum -type=Permission -trimprefix=Permission -kind=flags
+type Permission uint8
+
+const (
+	PermissionRead Permission = 1 << iota
+	PermissionWrite
+	PermissionExecute
+)
@@ -0,0 +1,9 @@
+package marshalenum
+
+//go:generate genum -type=Status -trimprefix=Status -marshal=json,sql,text
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusPaused Status = "paused"
+)
@@ -0,0 +1,9 @@
+package marshalenumint
+
+//go:generate genum -type=Status -trimprefix=Status -marshal=json,sql,text -wireformat=value
+type Status int
+
+const (
+	StatusActive Status = 1
+	StatusPaused Status = 2
+)
@@ -0,0 +1,9 @@
+package marshalenumjsononly
+
+//go:generate genum -type=Status -trimprefix=Status -marshal=json
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusPaused Status = "paused"
+)
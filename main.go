@@ -1,30 +1,53 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/sv3tluv/genum/internal"
+	"github.com/sv3tluv/genum/internal/exhaustive"
 )
 
 func main() {
-	loader := internal.NewLoader()
-	env, err := loader.Load()
-	if err != nil {
-		fail("%v", err)
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		runCheck(os.Args[2:])
+		return
 	}
 
-	parser := internal.NewParser()
-	files, err := parser.Parse(env)
+	loader := internal.NewLoader()
+	envs, err := loader.Load()
 	if err != nil {
 		fail("%v", err)
 	}
 
+	parser := internal.NewParser()
 	generator := internal.NewGenerator()
-	for _, file := range files {
-		if err = generator.Generate(file); err != nil {
+	for _, env := range envs {
+		files, err := parser.Parse(&env)
+		if err != nil {
 			fail("%v", err)
 		}
+
+		for _, file := range files {
+			if err = generator.Generate(file); err != nil {
+				fail("%v", err)
+			}
+		}
+	}
+}
+
+func runCheck(args []string) {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	includeGenerated := fs.Bool("include-generated", false, "also check generated files")
+	_ = fs.Parse(args)
+
+	count, err := exhaustive.Run(fs.Args(), *includeGenerated)
+	if err != nil {
+		fail("%v", err)
+	}
+	if count > 0 {
+		os.Exit(1)
 	}
 }
 